@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultConfigDirName is where per-repo session configs live under the user's config dir.
+const defaultConfigDirName = "tmux-sessionizer"
+
+// localConfigFileName is the name of an in-repo override for a project's session config.
+const localConfigFileName = ".tmux-sessionizer.yml"
+
+// TabConfig describes a single tmux window: its name and the commands to run in it.
+type TabConfig struct {
+	Name     string   `yaml:"name"`
+	Commands []string `yaml:"commands"`
+}
+
+// SessionConfig describes how a repo's tmux session should be laid out.
+//
+// Recency/frequency for picker ordering is tracked separately in state.go's
+// Store, which is the single source of truth for frecency.
+type SessionConfig struct {
+	SessionName string      `yaml:"session_name"`
+	WorkingDir  string      `yaml:"working_dir"`
+	Tabs        []TabConfig `yaml:"tabs"`
+}
+
+// defaultSessionConfig returns the hardcoded nvim/server/term layout used when no
+// project-level config exists.
+func defaultSessionConfig(name, directory string) *SessionConfig {
+	return &SessionConfig{
+		SessionName: name,
+		WorkingDir:  directory,
+		Tabs: []TabConfig{
+			{Name: "nvim", Commands: []string{"nvim"}},
+			{Name: "server"},
+			{Name: "term"},
+		},
+	}
+}
+
+// userConfigPath returns the path of the per-repo config kept in the user's config dir.
+func userConfigPath(repoName string) (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, defaultConfigDirName, repoName+".yml"), nil
+}
+
+// localConfigPath returns the path of an in-repo session config override.
+func localConfigPath(repoDir string) string {
+	return filepath.Join(repoDir, localConfigFileName)
+}
+
+// loadSessionConfig looks for a project-level session config, checking the in-repo
+// override before the user config dir, and falls back to the default layout when
+// neither exists (generating one in the user config dir so it's there to
+// customize next time).
+func loadSessionConfig(repoName, repoDir string) (*SessionConfig, error) {
+	candidates := []string{localConfigPath(repoDir)}
+	if p, err := userConfigPath(repoName); err == nil {
+		candidates = append(candidates, p)
+	}
+
+	for _, path := range candidates {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("reading session config %s: %w", path, err)
+		}
+
+		cfg := &SessionConfig{}
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("parsing session config %s: %w", path, err)
+		}
+		if cfg.SessionName == "" {
+			cfg.SessionName = repoName
+		}
+		if cfg.WorkingDir == "" {
+			cfg.WorkingDir = repoDir
+		}
+		return cfg, nil
+	}
+
+	cfg := defaultSessionConfig(repoName, repoDir)
+	if genPath, err := userConfigPath(repoName); err == nil {
+		if err := saveSessionConfig(genPath, cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not generate default session config: %v\n", err)
+		}
+	}
+	return cfg, nil
+}
+
+// saveSessionConfig writes cfg to path, creating parent directories as needed.
+func saveSessionConfig(path string, cfg *SessionConfig) error {
+	if path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating config dir: %w", err)
+	}
+
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("marshaling session config: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing session config %s: %w", path, err)
+	}
+	return nil
+}
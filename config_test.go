@@ -0,0 +1,99 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestLoadSessionConfigLocalOverrideTakesPrecedence(t *testing.T) {
+	withTempConfigHome(t)
+	repoDir := t.TempDir()
+
+	local := "session_name: local-session\nworking_dir: " + repoDir + "\ntabs:\n  - name: nvim\n    commands: [nvim]\n"
+	if err := os.WriteFile(localConfigPath(repoDir), []byte(local), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	userPath, err := userConfigPath("my-repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(userPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(userPath, []byte("session_name: user-session\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := loadSessionConfig("my-repo", repoDir)
+	if err != nil {
+		t.Fatalf("loadSessionConfig() error = %v", err)
+	}
+	if cfg.SessionName != "local-session" {
+		t.Errorf("SessionName = %q, want %q (local override should win)", cfg.SessionName, "local-session")
+	}
+}
+
+func TestLoadSessionConfigDefaultsMissingFields(t *testing.T) {
+	withTempConfigHome(t)
+	repoDir := t.TempDir()
+
+	// Local override present, but without session_name/working_dir set.
+	if err := os.WriteFile(localConfigPath(repoDir), []byte("tabs:\n  - name: term\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := loadSessionConfig("my-repo", repoDir)
+	if err != nil {
+		t.Fatalf("loadSessionConfig() error = %v", err)
+	}
+	if cfg.SessionName != "my-repo" {
+		t.Errorf("SessionName = %q, want repo name %q", cfg.SessionName, "my-repo")
+	}
+	if cfg.WorkingDir != repoDir {
+		t.Errorf("WorkingDir = %q, want repo dir %q", cfg.WorkingDir, repoDir)
+	}
+}
+
+func TestLoadSessionConfigGeneratesDefaultWhenNoneFound(t *testing.T) {
+	withTempConfigHome(t)
+	repoDir := t.TempDir()
+
+	cfg, err := loadSessionConfig("my-repo", repoDir)
+	if err != nil {
+		t.Fatalf("loadSessionConfig() error = %v", err)
+	}
+	if len(cfg.Tabs) != 3 {
+		t.Fatalf("loadSessionConfig() Tabs = %v, want the 3 default tabs", cfg.Tabs)
+	}
+
+	genPath, err := userConfigPath("my-repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(genPath)
+	if err != nil {
+		t.Fatalf("generated config was not written to %s: %v", genPath, err)
+	}
+
+	written := &SessionConfig{}
+	if err := yaml.Unmarshal(data, written); err != nil {
+		t.Fatalf("generated config is not valid YAML: %v", err)
+	}
+	if written.SessionName != "my-repo" {
+		t.Errorf("generated config SessionName = %q, want %q", written.SessionName, "my-repo")
+	}
+	if len(written.Tabs) != 3 {
+		t.Errorf("generated config Tabs = %v, want the 3 default tabs", written.Tabs)
+	}
+}
+
+func TestSaveSessionConfigEmptyPathIsNoop(t *testing.T) {
+	if err := saveSessionConfig("", defaultSessionConfig("repo", "/tmp/repo")); err != nil {
+		t.Errorf("saveSessionConfig(\"\", ...) error = %v, want nil", err)
+	}
+}
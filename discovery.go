@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DiscoveryOptions configures how findGitRepos walks the filesystem.
+type DiscoveryOptions struct {
+	// Concurrency is the number of directories scanned in parallel.
+	Concurrency int
+	// MaxDepth is how many levels below root to descend before giving up.
+	MaxDepth int
+	// IncludeWorktrees also enumerates each repo's linked worktrees as separate
+	// entries, labeled "repo(branch)".
+	IncludeWorktrees bool
+}
+
+// RepoEntry is a single pickable entry in the repo list: a path on disk and the
+// label to show for it (normally the directory name, or "repo(branch)" for a
+// linked worktree).
+type RepoEntry struct {
+	Path  string
+	Label string
+}
+
+// discoveryTask is one unit of work in the discovery worker pool: a directory to
+// inspect at a given depth below the search root.
+type discoveryTask struct {
+	path  string
+	depth int
+}
+
+// findGitRepos searches for git repos below root using a bounded-depth worker
+// pool. It recognizes both ".git" directories and ".git" files (which point at a
+// worktree's or submodule's real gitdir elsewhere), and stops descending as soon
+// as a repo is found.
+func findGitRepos(root string, opts DiscoveryOptions) ([]RepoEntry, error) {
+	start := time.Now()
+
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	tasks := make(chan discoveryTask, 1024)
+	results := make(chan RepoEntry, 1024)
+
+	var pending sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	recordErr := func(err error) {
+		mu.Lock()
+		if firstErr == nil {
+			firstErr = err
+		}
+		mu.Unlock()
+	}
+
+	push := func(t discoveryTask) {
+		pending.Add(1)
+		tasks <- t
+	}
+
+	var workers sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for t := range tasks {
+				scanDir(t, opts, push, results, recordErr)
+				pending.Done()
+			}
+		}()
+	}
+
+	// Drain results concurrently with the workers: they're the only writers, and
+	// once the buffer fills they'd block on results <- ... forever if nothing
+	// read until after workers.Wait() returned.
+	var repos []RepoEntry
+	collected := make(chan struct{})
+	go func() {
+		defer close(collected)
+		for entry := range results {
+			repos = append(repos, entry)
+		}
+	}()
+
+	push(discoveryTask{path: root, depth: 0})
+
+	go func() {
+		pending.Wait()
+		close(tasks)
+	}()
+
+	workers.Wait()
+	close(results)
+	<-collected
+
+	mu.Lock()
+	err := firstErr
+	mu.Unlock()
+
+	log.Printf("discovery: scanned %s (depth=%d, concurrency=%d), found %d repos in %s", root, opts.MaxDepth, concurrency, len(repos), time.Since(start))
+	return repos, err
+}
+
+// scanDir inspects a single directory: if it's a git repo it's emitted as a
+// result (along with any linked worktrees, if requested) and not descended into;
+// otherwise its subdirectories are queued for scanning, depth permitting.
+func scanDir(t discoveryTask, opts DiscoveryOptions, push func(discoveryTask), results chan<- RepoEntry, recordErr func(error)) {
+	log.Printf("discovery: visiting %s (depth=%d)", t.path, t.depth)
+
+	if isGitRepo(t.path) {
+		log.Printf("discovery: found repo %s", t.path)
+		results <- RepoEntry{Path: t.path, Label: filepath.Base(t.path)}
+		if opts.IncludeWorktrees {
+			for _, wt := range linkedWorktrees(t.path) {
+				log.Printf("discovery: found worktree %s at %s", wt.Label, wt.Path)
+				results <- wt
+			}
+		}
+		return
+	}
+
+	if t.depth >= opts.MaxDepth {
+		return
+	}
+
+	entries, err := os.ReadDir(t.path)
+	if err != nil {
+		if os.IsPermission(err) {
+			fmt.Fprintf(os.Stderr, "Permission denied: %v\n", t.path)
+			log.Printf("discovery: skipped %s: permission denied", t.path)
+			return
+		}
+		recordErr(err)
+		return
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		push(discoveryTask{path: filepath.Join(t.path, entry.Name()), depth: t.depth + 1})
+	}
+}
+
+// isGitRepo checks if the given directory is a git repository, recognizing both a
+// ".git" directory (a normal repo) and a ".git" file (a linked worktree or
+// submodule checkout, which points at its real gitdir elsewhere).
+func isGitRepo(dir string) bool {
+	_, err := os.Lstat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+// linkedWorktrees reads a repo's .git/worktrees directory and returns each linked
+// worktree as its own entry, labeled "repo(branch)" so users can jump straight
+// into any active worktree.
+func linkedWorktrees(repoPath string) []RepoEntry {
+	worktreesDir := filepath.Join(repoPath, ".git", "worktrees")
+	entries, err := os.ReadDir(worktreesDir)
+	if err != nil {
+		return nil
+	}
+
+	repoName := filepath.Base(repoPath)
+	var worktrees []RepoEntry
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		worktreeMeta := filepath.Join(worktreesDir, entry.Name())
+		gitdir, err := os.ReadFile(filepath.Join(worktreeMeta, "gitdir"))
+		if err != nil {
+			continue
+		}
+		worktreeDir := filepath.Dir(strings.TrimSpace(string(gitdir)))
+		branch := worktreeBranch(filepath.Join(worktreeMeta, "HEAD"))
+
+		worktrees = append(worktrees, RepoEntry{
+			Path:  worktreeDir,
+			Label: fmt.Sprintf("%s(%s)", repoName, branch),
+		})
+	}
+	return worktrees
+}
+
+// worktreeBranch reads a linked worktree's HEAD file and returns the branch name
+// it points at, or "detached" if it isn't on a branch.
+func worktreeBranch(headPath string) string {
+	const refPrefix = "ref: refs/heads/"
+
+	data, err := os.ReadFile(headPath)
+	if err != nil {
+		return "detached"
+	}
+
+	head := strings.TrimSpace(string(data))
+	if strings.HasPrefix(head, refPrefix) {
+		return strings.TrimPrefix(head, refPrefix)
+	}
+	return "detached"
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func mkGitDir(t *testing.T, root string, i int) {
+	t.Helper()
+	dir := filepath.Join(root, fmt.Sprintf("repo%d", i))
+	if err := os.MkdirAll(filepath.Join(dir, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindGitReposDoesNotDeadlockOnManyRepos(t *testing.T) {
+	dir := t.TempDir()
+	for i := 0; i < 1500; i++ {
+		mkGitDir(t, dir, i)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		repos, err := findGitRepos(dir, DiscoveryOptions{Concurrency: 4, MaxDepth: 5})
+		if err != nil {
+			t.Errorf("findGitRepos() error = %v", err)
+		}
+		if len(repos) != 1500 {
+			t.Errorf("findGitRepos() found %d repos, want 1500", len(repos))
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("findGitRepos() did not return within 10s (deadlock)")
+	}
+}
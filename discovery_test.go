@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWorktreeBranch(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("missing HEAD file is detached", func(t *testing.T) {
+		if got := worktreeBranch(filepath.Join(dir, "does-not-exist")); got != "detached" {
+			t.Errorf("worktreeBranch() = %q, want %q", got, "detached")
+		}
+	})
+
+	t.Run("branch ref", func(t *testing.T) {
+		headPath := filepath.Join(dir, "HEAD-branch")
+		if err := os.WriteFile(headPath, []byte("ref: refs/heads/feature-x\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if got := worktreeBranch(headPath); got != "feature-x" {
+			t.Errorf("worktreeBranch() = %q, want %q", got, "feature-x")
+		}
+	})
+
+	t.Run("detached HEAD", func(t *testing.T) {
+		headPath := filepath.Join(dir, "HEAD-detached")
+		if err := os.WriteFile(headPath, []byte("a1b2c3d4\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if got := worktreeBranch(headPath); got != "detached" {
+			t.Errorf("worktreeBranch() = %q, want %q", got, "detached")
+		}
+	})
+}
+
+func TestLinkedWorktrees(t *testing.T) {
+	repoPath := t.TempDir()
+	worktreeDir := t.TempDir()
+
+	worktreeMeta := filepath.Join(repoPath, ".git", "worktrees", "feature-x")
+	if err := os.MkdirAll(worktreeMeta, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(worktreeMeta, "gitdir"), []byte(filepath.Join(worktreeDir, ".git")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(worktreeMeta, "HEAD"), []byte("ref: refs/heads/feature-x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	worktrees := linkedWorktrees(repoPath)
+	if len(worktrees) != 1 {
+		t.Fatalf("linkedWorktrees() returned %d entries, want 1", len(worktrees))
+	}
+
+	want := RepoEntry{Path: worktreeDir, Label: filepath.Base(repoPath) + "(feature-x)"}
+	if worktrees[0] != want {
+		t.Errorf("linkedWorktrees()[0] = %+v, want %+v", worktrees[0], want)
+	}
+}
+
+func TestLinkedWorktreesNoWorktreesDir(t *testing.T) {
+	if got := linkedWorktrees(t.TempDir()); got != nil {
+		t.Errorf("linkedWorktrees() = %v, want nil", got)
+	}
+}
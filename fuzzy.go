@@ -0,0 +1,90 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// fuzzyMatch reports whether query is a subsequence of target (case-insensitively)
+// and, if so, scores the match: higher scores go to matches that land on a
+// prefix or word boundary and run in unbroken streaks. This is what
+// lets a short query like "tsz" match "tmux-sessionizer".
+func fuzzyMatch(query, target string) (bool, int) {
+	if query == "" {
+		return true, 0
+	}
+
+	q := []rune(strings.ToLower(query))
+	t := []rune(strings.ToLower(target))
+
+	score := 0
+	qi := 0
+	streak := 0
+
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] != q[qi] {
+			streak = 0
+			continue
+		}
+
+		score++
+		if ti == 0 {
+			score += 10
+		} else if isWordBoundary(t[ti-1]) {
+			score += 5
+		}
+		streak++
+		if streak > 1 {
+			score += 3
+		}
+		qi++
+	}
+
+	return qi == len(q), score
+}
+
+// isWordBoundary reports whether r commonly precedes the start of a new "word"
+// in repo/file names, e.g. the '-' in "tmux-sessionizer".
+func isWordBoundary(r rune) bool {
+	switch r {
+	case '-', '_', '/', '.', ' ':
+		return true
+	default:
+		return false
+	}
+}
+
+// filterOptions returns the subset of options that fuzzy-match query, sorted by
+// descending match score with the original order as a tiebreaker. An empty query
+// returns options unchanged.
+func filterOptions(options []string, query string) []string {
+	if query == "" {
+		return options
+	}
+
+	type scored struct {
+		option string
+		score  int
+		index  int
+	}
+
+	matches := make([]scored, 0, len(options))
+	for i, option := range options {
+		if ok, score := fuzzyMatch(query, option); ok {
+			matches = append(matches, scored{option: option, score: score, index: i})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].index < matches[j].index
+	})
+
+	filtered := make([]string, len(matches))
+	for i, m := range matches {
+		filtered[i] = m.option
+	}
+	return filtered
+}
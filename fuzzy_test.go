@@ -0,0 +1,65 @@
+package main
+
+import "testing"
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		target string
+		wantOK bool
+	}{
+		{"empty query matches anything", "", "tmux-sessionizer", true},
+		{"subsequence across word boundaries", "tsz", "tmux-sessionizer", true},
+		{"case insensitive", "TMUX", "tmux-sessionizer", true},
+		{"out of order does not match", "zst", "tmux-sessionizer", false},
+		{"missing characters do not match", "xyz", "tmux-sessionizer", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, _ := fuzzyMatch(tt.query, tt.target)
+			if ok != tt.wantOK {
+				t.Errorf("fuzzyMatch(%q, %q) ok = %v, want %v", tt.query, tt.target, ok, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFuzzyMatchScoresPrefixHigherThanMidString(t *testing.T) {
+	_, prefixScore := fuzzyMatch("tmux", "tmux-sessionizer")
+	_, midScore := fuzzyMatch("mux", "xxx-tmux-sessionizer")
+	if prefixScore <= midScore {
+		t.Errorf("expected prefix match score (%d) to beat mid-string match score (%d)", prefixScore, midScore)
+	}
+}
+
+func TestFilterOptions(t *testing.T) {
+	options := []string{"tmux-sessionizer", "other-repo", "totally-unrelated"}
+
+	got := filterOptions(options, "tsz")
+	want := []string{"tmux-sessionizer"}
+	if len(got) != len(want) {
+		t.Fatalf("filterOptions() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("filterOptions()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestFilterOptionsEmptyQueryReturnsAllUnchanged(t *testing.T) {
+	options := []string{"b-repo", "a-repo"}
+	got := filterOptions(options, "")
+	if len(got) != 2 || got[0] != "b-repo" || got[1] != "a-repo" {
+		t.Errorf("filterOptions(options, \"\") = %v, want unchanged %v", got, options)
+	}
+}
+
+func TestFilterOptionsNoMatches(t *testing.T) {
+	got := filterOptions([]string{"foo", "bar"}, "zzz")
+	if len(got) != 0 {
+		t.Errorf("filterOptions() = %v, want empty", got)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// logEnvVar, when set to a file path, turns on debug logging for both the
+// bubbletea TUI and the discovery/tmux operations. Unset by default so the
+// tool stays quiet on user machines until they opt in.
+const logEnvVar = "TMUX_SESSIONIZER_LOG"
+
+// setupLogging wires the stdlib log package (used by discovery.go, tmuxcmd, and
+// main) to TMUX_SESSIONIZER_LOG, and hands back a cleanup func to close the log
+// file. When the env var is unset, logging is a no-op.
+func setupLogging() (cleanup func(), err error) {
+	path := os.Getenv(logEnvVar)
+	if path == "" {
+		log.SetOutput(io.Discard)
+		return func() {}, nil
+	}
+
+	file, err := tea.LogToFile(path, "tmux-sessionizer")
+	if err != nil {
+		log.SetOutput(io.Discard)
+		return func() {}, fmt.Errorf("opening log file %s: %w", path, err)
+	}
+	return func() { file.Close() }, nil
+}
@@ -1,198 +1,119 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"log"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"strings"
+	"runtime"
 
 	tea "github.com/charmbracelet/bubbletea"
-)
-
-// isGitRepo checks if the given directory is a git repository
-func isGitRepo(dir string) bool {
-	gitDir := filepath.Join(dir, ".git")
-	info, err := os.Stat(gitDir)
-	return err == nil && info.IsDir()
-}
 
-// findGitRepos searches for git repos recursively from the given root
-func findGitRepos(root string) ([]string, error) {
-	var repos []string
+	"github.com/Haptic-Labs/tmux-sessionizer/tmuxcmd"
+)
 
-	// Check if the root directory itself is a git repository
-	if isGitRepo(root) {
-		repos = append(repos, root)
+// getDirectoryNames builds a label->path mapping for a list of discovered repos,
+// using each entry's display label (the directory name, or "repo(branch)" for a
+// linked worktree).
+func getDirectoryNames(entries []RepoEntry) map[string]string {
+	dirMap := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		dirMap[entry.Label] = entry.Path
 	}
-
-	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			// Skip directories we can't access
-			if os.IsPermission(err) {
-				fmt.Fprintf(os.Stderr, "Permission denied: %v\n", path)
-				return filepath.SkipDir
-			}
-			return err
-		}
-
-		// Skip the root directory since we've already checked it
-		if path == root {
-			return nil
-		}
-
-		// Skip hidden directories (those starting with .)
-		if info.IsDir() && strings.HasPrefix(filepath.Base(path), ".") && path != root {
-			return filepath.SkipDir
-		}
-
-		// If this directory is a git repository, add it to our list
-		if info.IsDir() && isGitRepo(path) {
-			repos = append(repos, path)
-			return filepath.SkipDir // Skip traversing into git repositories
-		}
-
-		return nil
-	})
-
-	return repos, err
+	return dirMap
 }
 
-// getDirectoryNames extracts just the directory names (not full paths) from a list of paths
-func getDirectoryNames(paths []string) map[string]string {
-	// Using a map to store name->path mapping
-	dirMap := make(map[string]string)
+// createTmuxSession creates (or attaches to) a tmux session for the given repo,
+// laying out its windows according to the repo's session config.
+func createTmuxSession(name, directory string) error {
+	cfg, err := loadSessionConfig(name, directory)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not load session config: %v\n", err)
+		cfg = defaultSessionConfig(name, directory)
+	}
 
-	for _, path := range paths {
-		name := filepath.Base(path)
-		dirMap[name] = path
+	store, storePath, err := loadStore()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not load attach history: %v\n", err)
 	}
+	store.recordAttach(directory)
+	defer func() {
+		if err := store.save(storePath); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not save attach history: %v\n", err)
+		}
+	}()
 
-	return dirMap
-}
+	attachOpts := &tmuxcmd.RunOpts{Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr}
 
-// createTmuxSession creates a new tmux session with the specified name and directory
-func createTmuxSession(name, directory string) error {
 	// Check if session already exists
-	checkCmd := exec.Command("tmux", "has-session", "-t", name)
-	err := checkCmd.Run()
-
-	if err == nil {
+	if err := tmuxcmd.HasSession(name).Run(nil); err == nil {
 		// Session exists, attach to it
-		attachCmd := exec.Command("tmux", "attach", "-t", name)
-		attachCmd.Stdin = os.Stdin
-		attachCmd.Stdout = os.Stdout
-		attachCmd.Stderr = os.Stderr
-		return attachCmd.Run()
+		return tmuxcmd.Attach(name).Run(attachOpts)
 	}
 
-	// Create new session with first window named "nvim"
-	createCmd := exec.Command("tmux", "new-session", "-d", "-s", name, "-c", directory, "-n", "nvim")
-	if err := createCmd.Run(); err != nil {
-		return err
+	if len(cfg.Tabs) == 0 {
+		cfg.Tabs = defaultSessionConfig(name, directory).Tabs
 	}
 
-	// Run nvim in the first window
-	nvimCmd := exec.Command("tmux", "send-keys", "-t", name+":0", "nvim", "Enter")
-	if err := nvimCmd.Run(); err != nil {
+	// Create new session with the first configured window
+	firstTab := cfg.Tabs[0]
+	if err := tmuxcmd.NewSession(name, firstTab.Name, directory).Run(nil); err != nil {
 		return err
 	}
-
-	// Create second window named "server"
-	serverCmd := exec.Command("tmux", "new-window", "-t", name+":1", "-n", "server", "-c", directory)
-	if err := serverCmd.Run(); err != nil {
+	if err := sendTabCommands(name, 0, firstTab); err != nil {
 		return err
 	}
 
-	// Create third window named "term"
-	termCmd := exec.Command("tmux", "new-window", "-t", name+":2", "-n", "term", "-c", directory)
-	if err := termCmd.Run(); err != nil {
-		return err
+	// Create the remaining configured windows
+	for i, tab := range cfg.Tabs[1:] {
+		index := i + 1
+		if err := tmuxcmd.NewWindow(tmuxcmd.Target{Session: name, Window: index}, tab.Name, directory).Run(nil); err != nil {
+			return err
+		}
+		if err := sendTabCommands(name, index, tab); err != nil {
+			return err
+		}
 	}
 
 	// Select the first window
-	selectCmd := exec.Command("tmux", "select-window", "-t", name+":0")
-	if err := selectCmd.Run(); err != nil {
+	if err := tmuxcmd.SelectWindow(tmuxcmd.Target{Session: name, Window: 0}).Run(nil); err != nil {
 		return err
 	}
 
 	// Attach to the session
-	attachCmd := exec.Command("tmux", "attach", "-t", name)
-	attachCmd.Stdin = os.Stdin
-	attachCmd.Stdout = os.Stdout
-	attachCmd.Stderr = os.Stderr
-	return attachCmd.Run()
+	return tmuxcmd.Attach(name).Run(attachOpts)
 }
 
-// model represents the bubbletea UI state
-type model struct {
-	options  []string
-	cursor   int
-	selected int
-	dirMap   map[string]string
-}
-
-// initialModel initializes the bubbletea model
-func initialModel(options []string, dirMap map[string]string) model {
-	return model{
-		options:  options,
-		cursor:   0,
-		selected: -1,
-		dirMap:   dirMap,
-	}
-}
-
-// Init is the bubbletea initialization function
-func (m model) Init() tea.Cmd {
-	return nil
-}
-
-// Update is the bubbletea update function that handles messages
-func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "q":
-			return m, tea.Quit
-		case "up", "k":
-			if m.cursor > 0 {
-				m.cursor--
-			}
-		case "down", "j":
-			if m.cursor < len(m.options)-1 {
-				m.cursor++
-			}
-		case "enter", " ":
-			m.selected = m.cursor
-			return m, tea.Quit
+// sendTabCommands runs a tab's configured commands in its tmux window, in order.
+func sendTabCommands(sessionName string, windowIndex int, tab TabConfig) error {
+	target := tmuxcmd.Target{Session: sessionName, Window: windowIndex}
+	for _, command := range tab.Commands {
+		if err := tmuxcmd.SendKeys(target, command).Run(nil); err != nil {
+			return err
 		}
 	}
-	return m, nil
+	return nil
 }
 
-// View is the bubbletea view function that renders the UI
-func (m model) View() string {
-	s := "Select a repository:\n\n"
+func main() {
+	concurrency := flag.Int("j", runtime.NumCPU(), "number of directories to scan concurrently")
+	maxDepth := flag.Int("depth", 5, "maximum directory depth to search below the root")
+	includeWorktrees := flag.Bool("worktrees", false, "also list each repo's linked worktrees as separate entries")
+	flag.Parse()
 
-	for i, option := range m.options {
-		cursor := " "
-		if m.cursor == i {
-			cursor = ">"
-		}
-		s += fmt.Sprintf("%s %s\n", cursor, option)
+	cleanupLogging, err := setupLogging()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not set up logging: %v\n", err)
 	}
+	defer cleanupLogging()
 
-	s += "\nPress q to quit.\n"
-	return s
-}
-
-func main() {
 	var searchDir string
 
 	// Check if a directory argument was provided
-	if len(os.Args) > 1 {
+	if flag.NArg() > 0 {
 		// Use the provided directory
-		providedDir := os.Args[1]
+		providedDir := flag.Arg(0)
 		absDir, err := filepath.Abs(providedDir)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error resolving path: %v\n", err)
@@ -212,7 +133,11 @@ func main() {
 	fmt.Printf("Searching for git repositories in: %s\n", searchDir)
 
 	// Find git repositories
-	repos, err := findGitRepos(searchDir)
+	repos, err := findGitRepos(searchDir, DiscoveryOptions{
+		Concurrency:      *concurrency,
+		MaxDepth:         *maxDepth,
+		IncludeWorktrees: *includeWorktrees,
+	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error finding git repositories: %v\n", err)
 		os.Exit(1)
@@ -232,6 +157,14 @@ func main() {
 		options = append(options, name)
 	}
 
+	// Rank by frecency (recency weighted by frequency) so recently-used repos
+	// surface first, falling back to alphabetical order.
+	store, _, err := loadStore()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not load attach history: %v\n", err)
+	}
+	sortByFrecency(options, dirMap, store)
+
 	// Create bubbletea model for repository selection
 	p := tea.NewProgram(initialModel(options, dirMap))
 	result, err := p.Run()
@@ -254,6 +187,7 @@ func main() {
 
 	selected := options[m.selected]
 	selectedPath := dirMap[selected]
+	log.Printf("selected session: %s (%s)", selected, selectedPath)
 
 	// Create tmux session
 	err = createTmuxSession(selected, selectedPath)
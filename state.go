@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// stateFileName is where repo attach history is persisted, under the user config dir.
+const stateFileName = "state.json"
+
+// RepoState tracks how recently and how often a repo has been attached to, so the
+// picker can rank it by frecency.
+type RepoState struct {
+	LastAttached time.Time `json:"last_attached"`
+	AttachCount  int       `json:"attach_count"`
+}
+
+// Store is the persisted attach history for all known repos, keyed by repo path.
+type Store struct {
+	Repos map[string]RepoState `json:"repos"`
+}
+
+// statePath returns the path of the persistent state file in the user's config dir.
+func statePath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, defaultConfigDirName, stateFileName), nil
+}
+
+// loadStore reads the persistent state file, returning an empty store if it doesn't
+// exist yet.
+func loadStore() (*Store, string, error) {
+	path, err := statePath()
+	if err != nil {
+		return &Store{Repos: map[string]RepoState{}}, "", err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Store{Repos: map[string]RepoState{}}, path, nil
+		}
+		return &Store{Repos: map[string]RepoState{}}, path, fmt.Errorf("reading state file %s: %w", path, err)
+	}
+
+	store := &Store{}
+	if err := json.Unmarshal(data, store); err != nil {
+		return &Store{Repos: map[string]RepoState{}}, path, fmt.Errorf("parsing state file %s: %w", path, err)
+	}
+	if store.Repos == nil {
+		store.Repos = map[string]RepoState{}
+	}
+	return store, path, nil
+}
+
+// save writes the store to path, creating parent directories as needed.
+func (s *Store) save(path string) error {
+	if path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating state dir: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling state: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing state file %s: %w", path, err)
+	}
+	return nil
+}
+
+// recordAttach bumps a repo's attach count and last-attached timestamp.
+func (s *Store) recordAttach(repoPath string) {
+	state := s.Repos[repoPath]
+	state.LastAttached = time.Now()
+	state.AttachCount++
+	s.Repos[repoPath] = state
+}
+
+// frecencyHalfLife is how long it takes an attach's contribution to the frecency
+// score to decay by half.
+const frecencyHalfLife = 14 * 24 * time.Hour
+
+// frecencyScore weighs how often a repo has been attached to by how recently,
+// using exponential decay so recent activity dominates over a long tail of old
+// attaches.
+func frecencyScore(state RepoState, now time.Time) float64 {
+	if state.AttachCount == 0 {
+		return 0
+	}
+	age := now.Sub(state.LastAttached)
+	if age < 0 {
+		age = 0
+	}
+	decay := math.Exp(-float64(age) / float64(frecencyHalfLife) * math.Ln2)
+	return float64(state.AttachCount) * decay
+}
+
+// sortByFrecency orders repo names by descending frecency score (looked up by their
+// full path in dirMap), falling back to alphabetical order for ties or repos with
+// no recorded history.
+func sortByFrecency(names []string, dirMap map[string]string, store *Store) {
+	now := time.Now()
+	sort.SliceStable(names, func(i, j int) bool {
+		scoreI := frecencyScore(store.Repos[dirMap[names[i]]], now)
+		scoreJ := frecencyScore(store.Repos[dirMap[names[j]]], now)
+		if scoreI != scoreJ {
+			return scoreI > scoreJ
+		}
+		return names[i] < names[j]
+	})
+}
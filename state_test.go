@@ -0,0 +1,126 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFrecencyScore(t *testing.T) {
+	now := time.Now()
+
+	t.Run("zero attach count scores zero", func(t *testing.T) {
+		if got := frecencyScore(RepoState{}, now); got != 0 {
+			t.Errorf("frecencyScore() = %v, want 0", got)
+		}
+	})
+
+	t.Run("no decay at age zero", func(t *testing.T) {
+		state := RepoState{LastAttached: now, AttachCount: 3}
+		if got, want := frecencyScore(state, now), 3.0; got != want {
+			t.Errorf("frecencyScore() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("halves at the half-life", func(t *testing.T) {
+		state := RepoState{LastAttached: now.Add(-frecencyHalfLife), AttachCount: 4}
+		got := frecencyScore(state, now)
+		want := 2.0
+		if diff := got - want; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("frecencyScore() = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestSortByFrecencyTiebreaksAlphabetically(t *testing.T) {
+	dirMap := map[string]string{
+		"zebra": "/repos/zebra",
+		"apple": "/repos/apple",
+		"mango": "/repos/mango",
+	}
+	names := []string{"zebra", "apple", "mango"}
+
+	// No store history at all: every entry scores 0, so order should fall back
+	// to alphabetical.
+	store := &Store{Repos: map[string]RepoState{}}
+	sortByFrecency(names, dirMap, store)
+
+	want := []string{"apple", "mango", "zebra"}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("sortByFrecency() = %v, want %v", names, want)
+		}
+	}
+}
+
+func TestSortByFrecencyOrdersByScore(t *testing.T) {
+	dirMap := map[string]string{
+		"frequent": "/repos/frequent",
+		"rare":     "/repos/rare",
+	}
+	names := []string{"rare", "frequent"}
+
+	now := time.Now()
+	store := &Store{Repos: map[string]RepoState{
+		"/repos/frequent": {LastAttached: now, AttachCount: 10},
+		"/repos/rare":     {LastAttached: now, AttachCount: 1},
+	}}
+	sortByFrecency(names, dirMap, store)
+
+	if names[0] != "frequent" || names[1] != "rare" {
+		t.Errorf("sortByFrecency() = %v, want [frequent rare]", names)
+	}
+}
+
+func TestLoadStoreMissingFileReturnsEmptyStore(t *testing.T) {
+	withTempConfigHome(t)
+
+	store, path, err := loadStore()
+	if err != nil {
+		t.Fatalf("loadStore() error = %v", err)
+	}
+	if path == "" {
+		t.Fatal("loadStore() returned empty path")
+	}
+	if len(store.Repos) != 0 {
+		t.Errorf("loadStore() Repos = %v, want empty", store.Repos)
+	}
+}
+
+func TestStoreSaveAndLoadRoundTrip(t *testing.T) {
+	withTempConfigHome(t)
+
+	store, path, err := loadStore()
+	if err != nil {
+		t.Fatalf("loadStore() error = %v", err)
+	}
+	store.recordAttach("/repos/roundtrip")
+	store.recordAttach("/repos/roundtrip")
+
+	if err := store.save(path); err != nil {
+		t.Fatalf("Store.save() error = %v", err)
+	}
+
+	reloaded, reloadedPath, err := loadStore()
+	if err != nil {
+		t.Fatalf("loadStore() (reload) error = %v", err)
+	}
+	if reloadedPath != path {
+		t.Fatalf("loadStore() path = %q, want %q", reloadedPath, path)
+	}
+
+	state, ok := reloaded.Repos["/repos/roundtrip"]
+	if !ok {
+		t.Fatalf("reloaded store missing /repos/roundtrip, got %v", reloaded.Repos)
+	}
+	if state.AttachCount != 2 {
+		t.Errorf("reloaded AttachCount = %d, want 2", state.AttachCount)
+	}
+}
+
+// withTempConfigHome points os.UserConfigDir() at a fresh temp directory for
+// the duration of the test, so config/state file tests don't touch the real
+// user config dir and don't see state left behind by other tests.
+func withTempConfigHome(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+}
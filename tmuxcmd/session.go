@@ -0,0 +1,82 @@
+package tmuxcmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxSessionNameLen caps normalized session names well under tmux's internal
+// limits, leaving room for the ":window" suffix used to build targets.
+const maxSessionNameLen = 100
+
+// NormalizeSessionName rewrites name so it's safe to use as a tmux session
+// name: tmux treats '.' and ':' as reserved for target syntax, so they're
+// rewritten to distinct tokens rather than both collapsing to the same
+// character — otherwise "my.repo" and "my:repo" (or "my_repo") would coalesce
+// onto one session name and silently attach to the wrong repo. Empty names
+// fall back to "session", and overlong names are truncated.
+func NormalizeSessionName(name string) string {
+	name = strings.NewReplacer(".", "_dot_", ":", "_colon_").Replace(name)
+	if name == "" {
+		name = "session"
+	}
+	if len(name) > maxSessionNameLen {
+		name = name[:maxSessionNameLen]
+	}
+	return name
+}
+
+// Target identifies a specific window within a session, e.g. "repo:1". The
+// zero Window value means window 0, not the session as a whole.
+type Target struct {
+	Session string
+	Window  int
+}
+
+// String renders the target in tmux's "session:window" syntax, normalizing the
+// session name.
+func (t Target) String() string {
+	return fmt.Sprintf("%s:%d", NormalizeSessionName(t.Session), t.Window)
+}
+
+// HasSession builds a command that checks whether a session exists.
+func HasSession(name string) *Command {
+	return NewCommand("has-session").AddOptionValues("-t", NormalizeSessionName(name))
+}
+
+// Attach builds a command that attaches to an existing session.
+func Attach(name string) *Command {
+	return NewCommand("attach").AddOptionValues("-t", NormalizeSessionName(name))
+}
+
+// NewSession builds a command that creates a detached session with a first
+// window, rooted at dir.
+func NewSession(name, windowName, dir string) *Command {
+	return NewCommand("new-session").
+		AddOptionValues("-d").
+		AddOptionValues("-s", NormalizeSessionName(name)).
+		AddOptionValues("-c", dir).
+		AddOptionValues("-n", windowName)
+}
+
+// NewWindow builds a command that creates an additional window in an existing
+// session, rooted at dir.
+func NewWindow(target Target, windowName, dir string) *Command {
+	return NewCommand("new-window").
+		AddOptionValues("-t", target.String()).
+		AddOptionValues("-n", windowName).
+		AddOptionValues("-c", dir)
+}
+
+// SelectWindow builds a command that focuses the given window.
+func SelectWindow(target Target) *Command {
+	return NewCommand("select-window").AddOptionValues("-t", target.String())
+}
+
+// SendKeys builds a command that types keys into target's window and presses
+// Enter.
+func SendKeys(target Target, keys string) *Command {
+	return NewCommand("send-keys").
+		AddOptionValues("-t", target.String()).
+		AddDynamicArguments(keys, "Enter")
+}
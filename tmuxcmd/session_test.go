@@ -0,0 +1,55 @@
+package tmuxcmd
+
+import "testing"
+
+func TestNormalizeSessionName(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain name is unchanged", "my-repo", "my-repo"},
+		{"dot is rewritten", "my.repo", "my_dot_repo"},
+		{"colon is rewritten", "my:repo", "my_colon_repo"},
+		{"empty name falls back", "", "session"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeSessionName(tt.in); got != tt.want {
+				t.Errorf("NormalizeSessionName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeSessionNameTruncatesOverlongNames(t *testing.T) {
+	long := make([]byte, maxSessionNameLen+20)
+	for i := range long {
+		long[i] = 'a'
+	}
+
+	got := NormalizeSessionName(string(long))
+	if len(got) != maxSessionNameLen {
+		t.Errorf("NormalizeSessionName() len = %d, want %d", len(got), maxSessionNameLen)
+	}
+}
+
+func TestTargetString(t *testing.T) {
+	target := Target{Session: "my.repo", Window: 2}
+	if got, want := target.String(), "my_dot_repo:2"; got != want {
+		t.Errorf("Target.String() = %q, want %q", got, want)
+	}
+}
+
+func TestNormalizeSessionNameDoesNotCollideAcrossReservedChars(t *testing.T) {
+	names := []string{"my.repo", "my:repo", "my_repo"}
+	seen := map[string]string{}
+	for _, n := range names {
+		normalized := NormalizeSessionName(n)
+		if original, ok := seen[normalized]; ok {
+			t.Errorf("NormalizeSessionName(%q) and NormalizeSessionName(%q) both produced %q", original, n, normalized)
+		}
+		seen[normalized] = n
+	}
+}
@@ -0,0 +1,64 @@
+// Package tmuxcmd provides a small typed builder around the tmux CLI, analogous
+// to Gitea's git.Command: it normalizes session names against tmux's
+// restrictions and centralizes argument construction and error wrapping so
+// callers never hand-concatenate exec.Command arguments.
+package tmuxcmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os/exec"
+)
+
+// Command is a single tmux invocation being built up before it's run.
+type Command struct {
+	subcommand string
+	args       []string
+}
+
+// NewCommand starts building a tmux invocation for the given subcommand, e.g.
+// NewCommand("new-session").
+func NewCommand(subcommand string) *Command {
+	return &Command{subcommand: subcommand}
+}
+
+// AddOptionValues appends a flag and its value(s), e.g. AddOptionValues("-s", name).
+func (c *Command) AddOptionValues(opt string, values ...string) *Command {
+	c.args = append(c.args, opt)
+	c.args = append(c.args, values...)
+	return c
+}
+
+// AddDynamicArguments appends positional, non-flag arguments (e.g. keys to send),
+// named separately from AddOptionValues so callers can see at a glance which
+// arguments come from user-controlled input.
+func (c *Command) AddDynamicArguments(args ...string) *Command {
+	c.args = append(c.args, args...)
+	return c
+}
+
+// RunOpts configures how a Command is executed.
+type RunOpts struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// Run executes the built tmux command, wrapping any failure with the
+// subcommand name so errors are identifiable without inspecting exit codes.
+func (c *Command) Run(opts *RunOpts) error {
+	log.Printf("tmux: running %s %v", c.subcommand, c.args)
+
+	cmd := exec.Command("tmux", append([]string{c.subcommand}, c.args...)...)
+	if opts != nil {
+		cmd.Stdin = opts.Stdin
+		cmd.Stdout = opts.Stdout
+		cmd.Stderr = opts.Stderr
+	}
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("tmux %s: %w", c.subcommand, err)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// model represents the bubbletea UI state
+type model struct {
+	options  []string
+	filtered []string
+	filter   textinput.Model
+	cursor   int
+	selected int
+	dirMap   map[string]string
+}
+
+// initialModel initializes the bubbletea model
+func initialModel(options []string, dirMap map[string]string) model {
+	filter := textinput.New()
+	filter.Placeholder = "type to filter..."
+	filter.Focus()
+
+	return model{
+		options:  options,
+		filtered: options,
+		filter:   filter,
+		cursor:   0,
+		selected: -1,
+		dirMap:   dirMap,
+	}
+}
+
+// Init is the bubbletea initialization function
+func (m model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update is the bubbletea update function that handles messages
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "up", "ctrl+k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case "down", "ctrl+j":
+			if m.cursor < len(m.filtered)-1 {
+				m.cursor++
+			}
+			return m, nil
+		case "enter":
+			if len(m.filtered) > 0 {
+				m.selected = m.cursorIndexInOptions()
+			}
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.filter, cmd = m.filter.Update(msg)
+
+	m.filtered = filterOptions(m.options, m.filter.Value())
+	if m.cursor >= len(m.filtered) {
+		m.cursor = len(m.filtered) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+
+	return m, cmd
+}
+
+// cursorIndexInOptions resolves the currently highlighted filtered entry back to
+// its index in the original (unfiltered) options slice.
+func (m model) cursorIndexInOptions() int {
+	selectedName := m.filtered[m.cursor]
+	for i, option := range m.options {
+		if option == selectedName {
+			return i
+		}
+	}
+	return -1
+}
+
+// View is the bubbletea view function that renders the UI
+func (m model) View() string {
+	s := "Select a repository:\n\n"
+	s += m.filter.View() + "\n\n"
+
+	for i, option := range m.filtered {
+		cursor := " "
+		if m.cursor == i {
+			cursor = ">"
+		}
+		s += fmt.Sprintf("%s %s\n", cursor, option)
+	}
+
+	s += "\nPress enter to select, ctrl+c to quit.\n"
+	return s
+}